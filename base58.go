@@ -0,0 +1,183 @@
+package data
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Base58BTCAlphabet is the alphabet used by Bitcoin and most other
+// base58-encoded currencies.
+const Base58BTCAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Base58FlickrAlphabet is the alphabet used by Flickr for its short URLs.
+// It is the BTC alphabet with the case of its letters swapped; the digits
+// are unchanged.
+const Base58FlickrAlphabet = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+
+var (
+	// Base58BTCEncoder encodes Bytes as base58, using the alphabet
+	// popularized by Bitcoin.
+	Base58BTCEncoder = base58Encoder{alphabet: Base58BTCAlphabet}
+
+	// Base58FlickrEncoder encodes Bytes as base58, using the alphabet
+	// Flickr uses for its short URLs.
+	Base58FlickrEncoder = base58Encoder{alphabet: Base58FlickrAlphabet}
+
+	// Base58Encoder is the default base58 ByteEncoder. Set
+	// `data.Encoder = data.Base58Encoder` to have all data.Bytes render
+	// as short, Bitcoin-style ids.
+	Base58Encoder = Base58BTCEncoder
+
+	// Base58CheckEncoder wraps Base58Encoder, adding a version byte and a
+	// trailing 4-byte double-SHA256 checksum, the same layout Bitcoin
+	// uses for addresses and WIF keys.
+	Base58CheckEncoder = base58CheckEncoder{base: Base58BTCEncoder}
+)
+
+// base58Encoder implements ByteEncoder, encoding the slice as base58 using
+// a configurable alphabet.
+type base58Encoder struct {
+	alphabet string
+}
+
+func (e base58Encoder) _assertByteEncoder() ByteEncoder {
+	return e
+}
+
+func (e base58Encoder) Marshal(bz []byte) ([]byte, error) {
+	return json.Marshal(e.encode(bz))
+}
+
+func (e base58Encoder) Unmarshal(dst *[]byte, src []byte) (err error) {
+	var s string
+	err = json.Unmarshal(src, &s)
+	if err != nil {
+		return errors.Wrap(err, "parse string")
+	}
+	*dst, err = e.decode(s)
+	return err
+}
+
+func (e base58Encoder) AppendMarshal(dst, src []byte) ([]byte, error) {
+	return defaultAppendMarshal(e, dst, src)
+}
+
+func (e base58Encoder) AppendUnmarshal(dst *[]byte, src []byte) error {
+	return defaultAppendUnmarshal(e, dst, src)
+}
+
+func (e base58Encoder) encode(input []byte) string {
+	if len(input) == 0 {
+		return ""
+	}
+
+	x := new(big.Int).SetBytes(input)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for x.Sign() > 0 {
+		x.DivMod(x, base, mod)
+		out = append(out, e.alphabet[mod.Int64()])
+	}
+
+	// every leading 0x00 byte becomes a leading alphabet[0] character
+	for _, b := range input {
+		if b != 0 {
+			break
+		}
+		out = append(out, e.alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+func (e base58Encoder) decode(s string) ([]byte, error) {
+	x := big.NewInt(0)
+	base := big.NewInt(58)
+
+	numZeros := 0
+	for numZeros < len(s) && s[numZeros] == e.alphabet[0] {
+		numZeros++
+	}
+
+	for _, r := range s {
+		idx := strings.IndexRune(e.alphabet, r)
+		if idx < 0 {
+			return nil, errors.Errorf("invalid base58 character %q", r)
+		}
+		x.Mul(x, base)
+		x.Add(x, big.NewInt(int64(idx)))
+	}
+
+	decoded := x.Bytes()
+	out := make([]byte, numZeros+len(decoded))
+	copy(out[numZeros:], decoded)
+	return out, nil
+}
+
+// base58CheckEncoder implements ByteEncoder, wrapping a base58Encoder with
+// a version byte and a double-SHA256 checksum. Unmarshal verifies the
+// checksum and strips both; the version byte is not checked.
+type base58CheckEncoder struct {
+	base    base58Encoder
+	version byte
+}
+
+func (e base58CheckEncoder) _assertByteEncoder() ByteEncoder {
+	return e
+}
+
+func (e base58CheckEncoder) Marshal(bz []byte) ([]byte, error) {
+	payload := make([]byte, 0, 1+len(bz)+4)
+	payload = append(payload, e.version)
+	payload = append(payload, bz...)
+	checksum := doubleSHA256(payload)
+	payload = append(payload, checksum[:4]...)
+	return json.Marshal(e.base.encode(payload))
+}
+
+func (e base58CheckEncoder) Unmarshal(dst *[]byte, src []byte) error {
+	var s string
+	if err := json.Unmarshal(src, &s); err != nil {
+		return errors.Wrap(err, "parse string")
+	}
+
+	payload, err := e.base.decode(s)
+	if err != nil {
+		return errors.Wrap(err, "decode base58")
+	}
+	if len(payload) < 5 {
+		return errors.Errorf("base58check: payload too short (%d bytes)", len(payload))
+	}
+
+	body, checksum := payload[:len(payload)-4], payload[len(payload)-4:]
+	want := doubleSHA256(body)
+	if !bytes.Equal(checksum, want[:4]) {
+		return errors.New("base58check: checksum mismatch")
+	}
+
+	*dst = body[1:]
+	return nil
+}
+
+func (e base58CheckEncoder) AppendMarshal(dst, src []byte) ([]byte, error) {
+	return defaultAppendMarshal(e, dst, src)
+}
+
+func (e base58CheckEncoder) AppendUnmarshal(dst *[]byte, src []byte) error {
+	return defaultAppendUnmarshal(e, dst, src)
+}
+
+func doubleSHA256(bz []byte) [32]byte {
+	first := sha256.Sum256(bz)
+	return sha256.Sum256(first[:])
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestBase58RoundTrip(t *testing.T) {
+	cases := [][]byte{
+		{},
+		{0x00},
+		{0x00, 0x00, 0x01},
+		{0xde, 0xad, 0xbe, 0xef},
+		[]byte("hello world"),
+	}
+
+	for _, enc := range []base58Encoder{Base58BTCEncoder, Base58FlickrEncoder} {
+		for _, want := range cases {
+			j, err := enc.Marshal(want)
+			if err != nil {
+				t.Fatalf("Marshal(%x): %v", want, err)
+			}
+
+			var got []byte
+			if err := enc.Unmarshal(&got, j); err != nil {
+				t.Fatalf("Unmarshal(%s): %v", j, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("round trip %x: got %x via %s", want, got, j)
+			}
+		}
+	}
+}
+
+func TestBase58Alphabets(t *testing.T) {
+	j, err := Base58BTCEncoder.Marshal([]byte("hello world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s string
+	if err := json.Unmarshal(j, &s); err != nil {
+		t.Fatal(err)
+	}
+	if s != "StV1DL6CwTryKyV" {
+		t.Errorf("BTC alphabet: got %q, want %q", s, "StV1DL6CwTryKyV")
+	}
+
+	jf, err := Base58FlickrEncoder.Marshal([]byte("hello world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sf string
+	if err := json.Unmarshal(jf, &sf); err != nil {
+		t.Fatal(err)
+	}
+	if sf == s {
+		t.Errorf("Flickr alphabet should differ in case from BTC alphabet, both gave %q", s)
+	}
+}
+
+func TestBase58InvalidCharacter(t *testing.T) {
+	var dst []byte
+	err := Base58BTCEncoder.Unmarshal(&dst, []byte(`"0OIl"`))
+	if err == nil {
+		t.Fatal("expected an error decoding characters excluded from the BTC alphabet")
+	}
+}
+
+func TestBase58CheckRoundTrip(t *testing.T) {
+	payloads := [][]byte{
+		{},
+		{0x01, 0x02, 0x03},
+		bytes.Repeat([]byte{0xff}, 32),
+	}
+
+	for _, want := range payloads {
+		j, err := Base58CheckEncoder.Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal(%x): %v", want, err)
+		}
+
+		var got []byte
+		if err := Base58CheckEncoder.Unmarshal(&got, j); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", j, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("round trip %x: got %x via %s", want, got, j)
+		}
+	}
+}
+
+func TestBase58CheckChecksumMismatch(t *testing.T) {
+	j, err := Base58CheckEncoder.Marshal([]byte{0x01, 0x02, 0x03})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s string
+	if err := json.Unmarshal(j, &s); err != nil {
+		t.Fatal(err)
+	}
+	// flip the last character of the encoded payload to corrupt the checksum
+	corrupted, err := json.Marshal(s[:len(s)-1] + flipBase58Char(s[len(s)-1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dst []byte
+	err = Base58CheckEncoder.Unmarshal(&dst, corrupted)
+	if err == nil {
+		t.Fatal("expected a checksum mismatch error")
+	}
+}
+
+func flipBase58Char(c byte) string {
+	for _, r := range Base58BTCAlphabet {
+		if byte(r) != c {
+			return string(r)
+		}
+	}
+	return string(c)
+}
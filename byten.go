@@ -0,0 +1,73 @@
+package data
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Byte20, Byte32, and Byte64 are fixed-length byte arrays for hashes and
+// addresses. Like Bytes, they marshal through the global Encoder, but
+// Unmarshal additionally rejects any input that doesn't decode to exactly
+// the right length, so a client can't smuggle a 20-byte payload into a
+// 32-byte hash field.
+//
+// Use these instead of hand-writing the four marshal/unmarshal methods
+// for every fixed-size array in your app, as ByteEncoder's doc comment
+// used to suggest - embed or alias one of these.
+
+// Byte20 is a fixed 20-byte value, e.g. an Ethereum-style address.
+type Byte20 [20]byte
+
+// Byte32 is a fixed 32-byte value, e.g. a SHA256 hash.
+type Byte32 [32]byte
+
+// Byte64 is a fixed 64-byte value, e.g. an ed25519 signature.
+type Byte64 [64]byte
+
+func (b Byte20) MarshalJSON() ([]byte, error) { return Encoder.Marshal(b[:]) }
+func (b Byte32) MarshalJSON() ([]byte, error) { return Encoder.Marshal(b[:]) }
+func (b Byte64) MarshalJSON() ([]byte, error) { return Encoder.Marshal(b[:]) }
+
+func (b *Byte20) UnmarshalJSON(data []byte) error { return unmarshalFixed(b[:], data) }
+func (b *Byte32) UnmarshalJSON(data []byte) error { return unmarshalFixed(b[:], data) }
+func (b *Byte64) UnmarshalJSON(data []byte) error { return unmarshalFixed(b[:], data) }
+
+func (b Byte20) MarshalText() ([]byte, error) { return Bytes(b[:]).MarshalText() }
+func (b Byte32) MarshalText() ([]byte, error) { return Bytes(b[:]).MarshalText() }
+func (b Byte64) MarshalText() ([]byte, error) { return Bytes(b[:]).MarshalText() }
+
+func (b *Byte20) UnmarshalText(text []byte) error { return unmarshalFixedText(b[:], text) }
+func (b *Byte32) UnmarshalText(text []byte) error { return unmarshalFixedText(b[:], text) }
+func (b *Byte64) UnmarshalText(text []byte) error { return unmarshalFixedText(b[:], text) }
+
+func (b Byte20) String() string { return Bytes(b[:]).String() }
+func (b Byte32) String() string { return Bytes(b[:]).String() }
+func (b Byte64) String() string { return Bytes(b[:]).String() }
+
+// Bytes returns a copy of the value as a plain slice.
+func (b Byte20) Bytes() []byte { return append([]byte{}, b[:]...) }
+func (b Byte32) Bytes() []byte { return append([]byte{}, b[:]...) }
+func (b Byte64) Bytes() []byte { return append([]byte{}, b[:]...) }
+
+// unmarshalFixed decodes src with the global Encoder and copies it into
+// dst, rejecting any decoded value whose length doesn't match dst exactly.
+func unmarshalFixed(dst []byte, src []byte) error {
+	var decoded []byte
+	if err := Encoder.Unmarshal(&decoded, src); err != nil {
+		return err
+	}
+	if len(decoded) != len(dst) {
+		return errors.Errorf("expected %d bytes, got %d", len(dst), len(decoded))
+	}
+	copy(dst, decoded)
+	return nil
+}
+
+func unmarshalFixedText(dst []byte, text []byte) error {
+	quoted, err := json.Marshal(string(text))
+	if err != nil {
+		return err
+	}
+	return unmarshalFixed(dst, quoted)
+}
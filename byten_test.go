@@ -0,0 +1,82 @@
+package data
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestByte32RoundTrip(t *testing.T) {
+	old := Encoder
+	defer func() { Encoder = old }()
+	Encoder = HexEncoder
+
+	var want Byte32
+	for i := range want {
+		want[i] = byte(i)
+	}
+
+	j, err := want.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Byte32
+	if err := got.UnmarshalJSON(j); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("round trip mismatch: got %x, want %x", got.Bytes(), want.Bytes())
+	}
+	if !bytes.Equal(got.Bytes(), want[:]) {
+		t.Errorf("Bytes() = %x, want %x", got.Bytes(), want[:])
+	}
+}
+
+func TestByte32TextRoundTrip(t *testing.T) {
+	old := Encoder
+	defer func() { Encoder = old }()
+	Encoder = B64Encoder
+
+	var want Byte32
+	for i := range want {
+		want[i] = byte(32 - i)
+	}
+
+	text, err := want.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Byte32
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("text round trip mismatch: got %x, want %x", got.Bytes(), want.Bytes())
+	}
+	if got.String() != string(text) {
+		t.Errorf("String() = %q, want %q", got.String(), text)
+	}
+}
+
+func TestByteNRejectsWrongLength(t *testing.T) {
+	old := Encoder
+	defer func() { Encoder = old }()
+	Encoder = HexEncoder
+
+	short := Bytes{0x01, 0x02}
+	j, err := short.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dst Byte32
+	if err := dst.UnmarshalJSON(j); err == nil {
+		t.Fatal("expected an error decoding a 2-byte payload into a Byte32")
+	}
+
+	var dst20 Byte20
+	if err := dst20.UnmarshalJSON(j); err == nil {
+		t.Fatal("expected an error decoding a 2-byte payload into a Byte20")
+	}
+}
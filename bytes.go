@@ -1,6 +1,7 @@
 package data
 
 import (
+	stdbytes "bytes"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -34,28 +35,126 @@ func (b *Bytes) UnmarshalJSON(data []byte) error {
 	return Encoder.Unmarshal(ref, data)
 }
 
+// MarshalText implements encoding.TextMarshaler, so a Bytes field renders
+// the same way under the global Encoder whether it goes through
+// encoding/json or a text-based codec such as YAML, TOML, or envconfig.
+func (b Bytes) MarshalText() ([]byte, error) {
+	jsonBz, err := b.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var s string
+	if err := json.Unmarshal(jsonBz, &s); err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, the text-codec
+// counterpart to UnmarshalJSON.
+func (b *Bytes) UnmarshalText(text []byte) error {
+	quoted, err := json.Marshal(string(text))
+	if err != nil {
+		return err
+	}
+	return b.UnmarshalJSON(quoted)
+}
+
+// String renders b under the global Encoder, or "" if the Encoder errors.
+func (b Bytes) String() string {
+	text, err := b.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// AppendJSON appends b's JSON encoding under the global Encoder to dst and
+// returns the extended buffer. It is meant for hot paths, such as loggers
+// and serializers, that append to a reusable buffer on every event
+// instead of allocating via MarshalJSON.
+//
+// If Encoder implements appendEncoder, its AppendMarshal is used directly;
+// otherwise the append is synthesized from Encoder.Marshal.
+func (b Bytes) AppendJSON(dst []byte) []byte {
+	if ae, ok := Encoder.(appendEncoder); ok {
+		if out, err := ae.AppendMarshal(dst, b); err == nil {
+			return out
+		}
+		return dst
+	}
+	out, _ := defaultAppendMarshal(Encoder, dst, b)
+	return out
+}
+
 // ByteEncoder handles both the marshalling and unmarshalling of
 // an arbitrary byte slice.
 //
 // All Bytes use the global Encoder set in this package.
-// If you want to use this encoding for byte arrays, you can just
-// implement a simple custom marshaller for your byte array
-//
-//   type Dings [64]byte
-//
-//   func (d Dings) MarshalJSON() ([]byte, error) {
-//     return data.Encoder.Marshal(d[:])
-//   }
-//
-//   func (d *Dings) UnmarshalJSON(data []byte) error {
-//     ref := (*d)[:]
-//     return data.Encoder.Unmarshal(&ref, data)
-//   }
+// If you want this encoding for a fixed-length byte array (a hash, an
+// address, ...), reach for Byte20, Byte32, or Byte64 rather than hand
+// writing the marshal/unmarshal methods yourself - they wrap the global
+// Encoder the same way Bytes does, with strict length checking on top.
 type ByteEncoder interface {
 	Marshal(bytes []byte) ([]byte, error)
 	Unmarshal(dst *[]byte, src []byte) error
 }
 
+// appendEncoder is an optional extension of ByteEncoder for implementations
+// that can append their encoding to an existing buffer without the
+// intermediate allocation Marshal/Unmarshal incur. It is deliberately kept
+// separate from ByteEncoder itself: ByteEncoder is a public extension
+// point that applications implement themselves, and folding these
+// methods into it would break every existing implementation the moment it
+// picked up this package. Callers that want the fast path, such as
+// Bytes.AppendJSON, type-assert for it and fall back to
+// defaultAppendMarshal/defaultAppendUnmarshal otherwise.
+type appendEncoder interface {
+	// AppendMarshal appends the JSON encoding of src to dst and returns
+	// the extended buffer, letting callers reuse a buffer across many
+	// values instead of allocating a new one per call via Marshal.
+	AppendMarshal(dst, src []byte) ([]byte, error)
+
+	// AppendUnmarshal decodes src and appends the result to *dst.
+	AppendUnmarshal(dst *[]byte, src []byte) error
+}
+
+// defaultAppendMarshal and defaultAppendUnmarshal give any ByteEncoder a
+// correct, if not allocation-free, AppendMarshal/AppendUnmarshal by
+// wrapping its Marshal/Unmarshal. Encoders on the hot path (hexEncoder,
+// base64Encoder) implement appendEncoder directly with zero-alloc
+// versions instead.
+func defaultAppendMarshal(e ByteEncoder, dst, src []byte) ([]byte, error) {
+	bz, err := e.Marshal(src)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, bz...), nil
+}
+
+func defaultAppendUnmarshal(e ByteEncoder, dst *[]byte, src []byte) error {
+	var bz []byte
+	if err := e.Unmarshal(&bz, src); err != nil {
+		return err
+	}
+	*dst = append(*dst, bz...)
+	return nil
+}
+
+// unquoteJSONString strips the surrounding quotes from a JSON string
+// literal without the allocation json.Unmarshal would incur. It is only
+// used by encoders whose alphabets never need escaping (hex, base64). It
+// trims surrounding whitespace but does not decode JSON escape sequences,
+// so a string written with escapes is rejected here even though
+// json.Unmarshal, and so Unmarshal on the same encoder, accepts it.
+func unquoteJSONString(src []byte) ([]byte, error) {
+	src = stdbytes.TrimSpace(src)
+	if len(src) < 2 || src[0] != '"' || src[len(src)-1] != '"' {
+		return nil, errors.New("expected JSON string")
+	}
+	return src[1 : len(src)-1], nil
+}
+
 // hexEncoder implements ByteEncoder encoding the slice as a hexidecimal
 // string
 type hexEncoder struct{}
@@ -80,6 +179,28 @@ func (_ hexEncoder) Marshal(bytes []byte) ([]byte, error) {
 	return json.Marshal(s)
 }
 
+func (_ hexEncoder) AppendMarshal(dst, src []byte) ([]byte, error) {
+	dst = append(dst, '"')
+	n := len(dst)
+	dst = append(dst, make([]byte, hex.EncodedLen(len(src)))...)
+	hex.Encode(dst[n:], src)
+	return append(dst, '"'), nil
+}
+
+func (_ hexEncoder) AppendUnmarshal(dst *[]byte, src []byte) error {
+	s, err := unquoteJSONString(src)
+	if err != nil {
+		return errors.Wrap(err, "parse string")
+	}
+	n := len(*dst)
+	*dst = append(*dst, make([]byte, hex.DecodedLen(len(s)))...)
+	if _, err := hex.Decode((*dst)[n:], s); err != nil {
+		*dst = (*dst)[:n]
+		return err
+	}
+	return nil
+}
+
 // base64Encoder implements ByteEncoder encoding the slice as
 // base64 url-safe encoding
 type base64Encoder struct {
@@ -104,3 +225,27 @@ func (e base64Encoder) Marshal(bytes []byte) ([]byte, error) {
 	s := e.EncodeToString(bytes)
 	return json.Marshal(s)
 }
+
+func (e base64Encoder) AppendMarshal(dst, src []byte) ([]byte, error) {
+	dst = append(dst, '"')
+	n := len(dst)
+	dst = append(dst, make([]byte, e.EncodedLen(len(src)))...)
+	e.Encode(dst[n:], src)
+	return append(dst, '"'), nil
+}
+
+func (e base64Encoder) AppendUnmarshal(dst *[]byte, src []byte) error {
+	s, err := unquoteJSONString(src)
+	if err != nil {
+		return errors.Wrap(err, "parse string")
+	}
+	n := len(*dst)
+	*dst = append(*dst, make([]byte, e.DecodedLen(len(s)))...)
+	written, err := e.Decode((*dst)[n:], s)
+	if err != nil {
+		*dst = (*dst)[:n]
+		return err
+	}
+	*dst = (*dst)[:n+written]
+	return nil
+}
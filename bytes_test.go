@@ -0,0 +1,99 @@
+package data
+
+import (
+	"bytes"
+	"testing"
+)
+
+// plainEncoder implements only the required ByteEncoder methods, so
+// Bytes.AppendJSON must fall back to defaultAppendMarshal for it instead
+// of type-asserting into appendEncoder.
+type plainEncoder struct{}
+
+func (plainEncoder) Marshal(bz []byte) ([]byte, error) {
+	return HexEncoder.Marshal(bz)
+}
+
+func (plainEncoder) Unmarshal(dst *[]byte, src []byte) error {
+	return HexEncoder.Unmarshal(dst, src)
+}
+
+func TestAppendMarshalMatchesMarshal(t *testing.T) {
+	payloads := [][]byte{{}, {0x00}, {0xde, 0xad, 0xbe, 0xef}, bytes.Repeat([]byte{0x07}, 40)}
+
+	for _, enc := range []ByteEncoder{HexEncoder, B64Encoder} {
+		ae := enc.(appendEncoder)
+		for _, p := range payloads {
+			want, err := enc.Marshal(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			prefix := []byte("buf:")
+			got, err := ae.AppendMarshal(append([]byte{}, prefix...), p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(prefix)+string(want) {
+				t.Errorf("AppendMarshal(%x) = %s, want %s%s", p, got, prefix, want)
+			}
+		}
+	}
+}
+
+func TestAppendUnmarshalMatchesUnmarshal(t *testing.T) {
+	payloads := [][]byte{{}, {0x00}, {0xde, 0xad, 0xbe, 0xef}, bytes.Repeat([]byte{0x07}, 40)}
+
+	for _, enc := range []ByteEncoder{HexEncoder, B64Encoder} {
+		ae := enc.(appendEncoder)
+		for _, p := range payloads {
+			j, err := enc.Marshal(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want []byte
+			if err := enc.Unmarshal(&want, j); err != nil {
+				t.Fatal(err)
+			}
+
+			prefix := []byte{0xAA, 0xBB}
+			dst := append([]byte{}, prefix...)
+			if err := ae.AppendUnmarshal(&dst, j); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(dst[len(prefix):], want) {
+				t.Errorf("AppendUnmarshal(%s) appended %x, want %x", j, dst[len(prefix):], want)
+			}
+		}
+	}
+}
+
+func TestBytesAppendJSON(t *testing.T) {
+	old := Encoder
+	defer func() { Encoder = old }()
+
+	b := Bytes{0x01, 0x02, 0x03}
+
+	Encoder = HexEncoder
+	want, err := b.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := b.AppendJSON([]byte("x:"))
+	if string(got) != "x:"+string(want) {
+		t.Errorf("AppendJSON with appendEncoder-backed Encoder = %s, want %s%s", got, "x:", want)
+	}
+
+	// plainEncoder does not implement appendEncoder, exercising the
+	// defaultAppendMarshal fallback path.
+	Encoder = plainEncoder{}
+	wantPlain, err := b.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotPlain := b.AppendJSON([]byte("y:"))
+	if string(gotPlain) != "y:"+string(wantPlain) {
+		t.Errorf("AppendJSON with plain Encoder = %s, want %s%s", gotPlain, "y:", wantPlain)
+	}
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PrefixedHexEncoder implements ByteEncoder, encoding the slice as
+// "0x"-prefixed hexadecimal, the convention used throughout the Ethereum
+// ecosystem (see go-ethereum's common/hexutil).
+//
+// Unmarshal accepts an optional "0x"/"0X" prefix and rejects odd-length
+// hex strings; "0x" alone decodes to an empty byte slice. Marshal always
+// emits a lowercase, "0x"-prefixed string.
+var PrefixedHexEncoder = prefixedHexEncoder{}
+
+type prefixedHexEncoder struct{}
+
+func (p prefixedHexEncoder) _assertByteEncoder() ByteEncoder {
+	return p
+}
+
+func (_ prefixedHexEncoder) Marshal(bz []byte) ([]byte, error) {
+	s := "0x" + hex.EncodeToString(bz)
+	return json.Marshal(s)
+}
+
+func (_ prefixedHexEncoder) Unmarshal(dst *[]byte, src []byte) error {
+	var s string
+	if err := json.Unmarshal(src, &s); err != nil {
+		return errors.Wrap(err, "parse string")
+	}
+
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	if len(s)%2 != 0 {
+		return errors.Errorf("hex string of odd length %d", len(s))
+	}
+
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		return errors.Wrap(err, "decode hex")
+	}
+	*dst = bz
+	return nil
+}
+
+func (p prefixedHexEncoder) AppendMarshal(dst, src []byte) ([]byte, error) {
+	return defaultAppendMarshal(p, dst, src)
+}
+
+func (p prefixedHexEncoder) AppendUnmarshal(dst *[]byte, src []byte) error {
+	return defaultAppendUnmarshal(p, dst, src)
+}
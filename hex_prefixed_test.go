@@ -0,0 +1,76 @@
+package data
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrefixedHexMarshal(t *testing.T) {
+	j, err := PrefixedHexEncoder.Marshal([]byte{0xde, 0xad, 0xbe, 0xef})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(j) != `"0xdeadbeef"` {
+		t.Errorf("got %s, want %q", j, `"0xdeadbeef"`)
+	}
+}
+
+func TestPrefixedHexUnmarshalAcceptsPrefix(t *testing.T) {
+	cases := []string{`"0xdeadbeef"`, `"0Xdeadbeef"`, `"deadbeef"`}
+	want := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	for _, in := range cases {
+		var dst []byte
+		if err := PrefixedHexEncoder.Unmarshal(&dst, []byte(in)); err != nil {
+			t.Errorf("Unmarshal(%s): %v", in, err)
+			continue
+		}
+		if !bytes.Equal(dst, want) {
+			t.Errorf("Unmarshal(%s) = %x, want %x", in, dst, want)
+		}
+	}
+}
+
+func TestPrefixedHexEmpty(t *testing.T) {
+	var dst []byte
+	if err := PrefixedHexEncoder.Unmarshal(&dst, []byte(`"0x"`)); err != nil {
+		t.Fatal(err)
+	}
+	if len(dst) != 0 {
+		t.Errorf("expected an empty slice, got %x", dst)
+	}
+}
+
+func TestPrefixedHexOddLengthRejected(t *testing.T) {
+	var dst []byte
+	err := PrefixedHexEncoder.Unmarshal(&dst, []byte(`"0xabc"`))
+	if err == nil {
+		t.Fatal("expected an error decoding an odd-length hex string")
+	}
+}
+
+func TestBytesTextMarshaling(t *testing.T) {
+	old := Encoder
+	defer func() { Encoder = old }()
+
+	for _, enc := range []ByteEncoder{HexEncoder, B64Encoder, PrefixedHexEncoder, Base58BTCEncoder} {
+		Encoder = enc
+
+		b := Bytes{0x01, 0x02, 0x03, 0xff}
+		text, err := b.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText: %v", err)
+		}
+
+		var got Bytes
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%s): %v", text, err)
+		}
+		if !bytes.Equal(got, b) {
+			t.Errorf("text round trip: got %x, want %x via %s", got, b, text)
+		}
+		if got.String() != string(text) {
+			t.Errorf("String() = %q, want %q", got.String(), text)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package data
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Mapper associates concrete types with a string type-name (and a single
+// disambiguation byte, which must be unique per Mapper but is not used by
+// ToJSON or FromJSON) so that values behind an interface field can
+// round-trip through JSON as a tagged envelope:
+//
+//   {"type": "ed25519", "value": {...}}
+//
+// This is modeled on go-wire's RegisterInterface. Build one Mapper per
+// interface, register every concrete implementation, then have a wrapper
+// struct embed the interface and delegate its MarshalJSON/UnmarshalJSON
+// to the Mapper's ToJSON/FromJSON:
+//
+//   var pubKeyMapper = data.NewMapper(new(PubKey)).
+//     RegisterImplementation(PubKeyEd25519{}, "ed25519", 0x01).
+//     RegisterImplementation(PubKeySecp256k1{}, "secp256k1", 0x02)
+//
+//   type PubKeyWrapper struct {
+//     PubKey
+//   }
+//
+//   func (w PubKeyWrapper) MarshalJSON() ([]byte, error) {
+//     return pubKeyMapper.ToJSON(w.PubKey)
+//   }
+//
+//   func (w *PubKeyWrapper) UnmarshalJSON(data []byte) error {
+//     v, err := pubKeyMapper.FromJSON(data)
+//     if err != nil {
+//       return err
+//     }
+//     w.PubKey = v.(PubKey)
+//     return nil
+//   }
+//
+// Bytes fields on the concrete types continue to honor the global
+// Encoder, since ToJSON/FromJSON marshal the concrete value with the
+// standard encoding/json package.
+type Mapper struct {
+	iface  reflect.Type
+	byName map[string]concreteType
+	byByte map[byte]concreteType
+	byType map[reflect.Type]concreteType
+}
+
+type concreteType struct {
+	Type     reflect.Type
+	Name     string
+	Disambig byte
+}
+
+// envelope is the wire format a Mapper reads and writes.
+type envelope struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// NewMapper creates a Mapper for the interface type pointed to by ptr,
+// e.g. NewMapper(new(PubKey)) for an interface named PubKey.
+func NewMapper(ptr interface{}) *Mapper {
+	rt := reflect.TypeOf(ptr)
+	if rt == nil || rt.Kind() != reflect.Ptr || rt.Elem().Kind() != reflect.Interface {
+		panic("data: NewMapper requires a pointer to an interface value")
+	}
+	return &Mapper{
+		iface:  rt.Elem(),
+		byName: map[string]concreteType{},
+		byByte: map[byte]concreteType{},
+		byType: map[reflect.Type]concreteType{},
+	}
+}
+
+// RegisterImplementation registers concrete as a possible value behind the
+// Mapper's interface, tagged with name in JSON. disambig is recorded and
+// checked for uniqueness only; nothing in the Mapper encodes it.
+// It returns the Mapper so registrations can be chained.
+func (m *Mapper) RegisterImplementation(concrete interface{}, name string, disambig byte) *Mapper {
+	typ := reflect.TypeOf(concrete)
+	if !typ.Implements(m.iface) {
+		panic(errors.Errorf("data: %v does not implement %v", typ, m.iface))
+	}
+	if _, ok := m.byName[name]; ok {
+		panic(errors.Errorf("data: name %q already registered", name))
+	}
+	if _, ok := m.byByte[disambig]; ok {
+		panic(errors.Errorf("data: disambiguation byte %x already registered", disambig))
+	}
+
+	ct := concreteType{Type: typ, Name: name, Disambig: disambig}
+	m.byName[name] = ct
+	m.byByte[disambig] = ct
+	m.byType[typ] = ct
+	return m
+}
+
+// ToJSON marshals value, which must be one of the Mapper's registered
+// implementations, into its {"type", "value"} envelope.
+func (m *Mapper) ToJSON(value interface{}) ([]byte, error) {
+	ct, ok := m.byType[reflect.TypeOf(value)]
+	if !ok {
+		return nil, errors.Errorf("data: %T is not registered with this mapper", value)
+	}
+
+	val, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal value")
+	}
+	return json.Marshal(envelope{Type: ct.Name, Value: val})
+}
+
+// FromJSON reads a {"type", "value"} envelope and returns the concrete
+// value it names, as the Mapper's interface type.
+func (m *Mapper) FromJSON(data []byte) (interface{}, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, errors.Wrap(err, "parse envelope")
+	}
+
+	ct, ok := m.byName[env.Type]
+	if !ok {
+		return nil, errors.Errorf("data: unregistered type %q", env.Type)
+	}
+
+	ptr := reflect.New(ct.Type)
+	if err := json.Unmarshal(env.Value, ptr.Interface()); err != nil {
+		return nil, errors.Wrap(err, "unmarshal value")
+	}
+	return ptr.Elem().Interface(), nil
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"bytes"
+	"testing"
+)
+
+type testAnimal interface {
+	Sound() string
+}
+
+type testDog struct {
+	Name Bytes `json:"name"`
+}
+
+func (d testDog) Sound() string { return "woof" }
+
+type testCat struct {
+	Lives int `json:"lives"`
+}
+
+func (c testCat) Sound() string { return "meow" }
+
+var testAnimalMapper = NewMapper(new(testAnimal)).
+	RegisterImplementation(testDog{}, "dog", 0x01).
+	RegisterImplementation(testCat{}, "cat", 0x02)
+
+// testAnimalWrapper is the pattern documented on Mapper: embed the
+// interface and delegate JSON (un)marshaling to the Mapper.
+type testAnimalWrapper struct {
+	testAnimal
+}
+
+func (w testAnimalWrapper) MarshalJSON() ([]byte, error) {
+	return testAnimalMapper.ToJSON(w.testAnimal)
+}
+
+func (w *testAnimalWrapper) UnmarshalJSON(data []byte) error {
+	v, err := testAnimalMapper.FromJSON(data)
+	if err != nil {
+		return err
+	}
+	w.testAnimal = v.(testAnimal)
+	return nil
+}
+
+func TestMapperRoundTrip(t *testing.T) {
+	old := Encoder
+	defer func() { Encoder = old }()
+	Encoder = HexEncoder
+
+	in := testAnimalWrapper{testDog{Name: Bytes("fido")}}
+	j, err := in.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out testAnimalWrapper
+	if err := out.UnmarshalJSON(j); err != nil {
+		t.Fatal(err)
+	}
+
+	dog, ok := out.testAnimal.(testDog)
+	if !ok {
+		t.Fatalf("expected a testDog, got %T", out.testAnimal)
+	}
+	if !bytes.Equal(dog.Name, in.testAnimal.(testDog).Name) {
+		t.Errorf("Name = %x, want %x", dog.Name, in.testAnimal.(testDog).Name)
+	}
+	if out.Sound() != "woof" {
+		t.Errorf("Sound() = %q, want %q", out.Sound(), "woof")
+	}
+}
+
+func TestMapperDispatchesToRegisteredType(t *testing.T) {
+	in := testAnimalWrapper{testCat{Lives: 9}}
+	j, err := in.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out testAnimalWrapper
+	if err := out.UnmarshalJSON(j); err != nil {
+		t.Fatal(err)
+	}
+	if out.Sound() != "meow" {
+		t.Errorf("Sound() = %q, want %q", out.Sound(), "meow")
+	}
+}
+
+func TestMapperUnregisteredTypeOnMarshal(t *testing.T) {
+	type unregistered struct{}
+	_, err := testAnimalMapper.ToJSON(unregistered{})
+	if err == nil {
+		t.Fatal("expected an error marshaling an unregistered type")
+	}
+}
+
+func TestMapperUnregisteredTypeOnUnmarshal(t *testing.T) {
+	_, err := testAnimalMapper.FromJSON([]byte(`{"type":"fish","value":{}}`))
+	if err == nil {
+		t.Fatal("expected an error for an unregistered type name")
+	}
+}
+
+func TestMapperRejectsDuplicateName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic registering a duplicate name")
+		}
+	}()
+	NewMapper(new(testAnimal)).
+		RegisterImplementation(testDog{}, "dog", 0x11).
+		RegisterImplementation(testCat{}, "dog", 0x12)
+}